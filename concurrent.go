@@ -0,0 +1,102 @@
+package gogridengine
+
+import (
+	"context"
+	"sync"
+)
+
+//ForEachConcurrent runs fn against every Job in the list using a bounded pool of goroutines. The first error
+//returned by fn cancels ctx and is propagated to the caller once all in-flight calls have finished.
+func (jl JobList) ForEachConcurrent(ctx context.Context, concurrency int, fn func(ctx context.Context, idx int, j Job) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	var errOnce sync.Once
+	var firstErr error
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				if err := fn(ctx, idx, jl[idx]); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for idx := range jl {
+		select {
+		case indexes <- idx:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return firstErr
+}
+
+//FilterConcurrent runs fn against every Job in the list using a bounded pool of goroutines and returns only the
+//Jobs for which fn returned true, preserving the original input order.
+func (jl JobList) FilterConcurrent(ctx context.Context, concurrency int, fn func(ctx context.Context, idx int, j Job) (bool, error)) (JobList, error) {
+	keep := make([]bool, len(jl))
+
+	err := jl.ForEachConcurrent(ctx, concurrency, func(ctx context.Context, idx int, j Job) error {
+		ok, err := fn(ctx, idx, j)
+		if err != nil {
+			return err
+		}
+
+		keep[idx] = ok
+		return nil
+	})
+	if err != nil {
+		return JobList{}, err
+	}
+
+	var jobs JobList
+	for idx, ok := range keep {
+		if ok {
+			jobs = append(jobs, jl[idx])
+		}
+	}
+
+	return jobs, nil
+}
+
+//MapConcurrent runs fn against every Job in the list using a bounded pool of goroutines and returns the
+//transformed JobList, preserving the original input order.
+func (jl JobList) MapConcurrent(ctx context.Context, concurrency int, fn func(ctx context.Context, idx int, j Job) (Job, error)) (JobList, error) {
+	mapped := make(JobList, len(jl))
+
+	err := jl.ForEachConcurrent(ctx, concurrency, func(ctx context.Context, idx int, j Job) error {
+		out, err := fn(ctx, idx, j)
+		if err != nil {
+			return err
+		}
+
+		mapped[idx] = out
+		return nil
+	})
+	if err != nil {
+		return JobList{}, err
+	}
+
+	return mapped, nil
+}
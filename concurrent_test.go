@@ -0,0 +1,94 @@
+package gogridengine
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func testJobList(n int) JobList {
+	jl := make(JobList, n)
+	for i := range jl {
+		jl[i] = Job{JBJobNumber: int64(i)}
+	}
+
+	return jl
+}
+
+func TestForEachConcurrentVisitsEveryJob(t *testing.T) {
+	jl := testJobList(50)
+
+	var visited int32
+	err := jl.ForEachConcurrent(context.Background(), 4, func(ctx context.Context, idx int, j Job) error {
+		atomic.AddInt32(&visited, 1)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if int(visited) != len(jl) {
+		t.Fatalf("visited %d jobs, want %d", visited, len(jl))
+	}
+}
+
+func TestForEachConcurrentPropagatesFirstError(t *testing.T) {
+	jl := testJobList(20)
+	wantErr := errors.New("boom")
+
+	err := jl.ForEachConcurrent(context.Background(), 4, func(ctx context.Context, idx int, j Job) error {
+		if idx == 5 {
+			return wantErr
+		}
+		return nil
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+}
+
+func TestFilterConcurrentPreservesOrder(t *testing.T) {
+	jl := testJobList(30)
+
+	filtered, err := jl.FilterConcurrent(context.Background(), 5, func(ctx context.Context, idx int, j Job) (bool, error) {
+		return j.JBJobNumber%2 == 0, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var last int64 = -1
+	for _, j := range filtered {
+		if j.JBJobNumber%2 != 0 {
+			t.Fatalf("got odd job number %d in filtered output", j.JBJobNumber)
+		}
+		if j.JBJobNumber <= last {
+			t.Fatalf("output not in original order: %d came after %d", j.JBJobNumber, last)
+		}
+		last = j.JBJobNumber
+	}
+}
+
+func TestMapConcurrentPreservesOrder(t *testing.T) {
+	jl := testJobList(30)
+
+	mapped, err := jl.MapConcurrent(context.Background(), 5, func(ctx context.Context, idx int, j Job) (Job, error) {
+		j.JobName = "mapped"
+		return j, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, j := range mapped {
+		if j.JBJobNumber != int64(i) {
+			t.Fatalf("mapped[%d].JBJobNumber = %d, want %d", i, j.JBJobNumber, i)
+		}
+		if j.JobName != "mapped" {
+			t.Fatalf("mapped[%d].JobName = %q, want \"mapped\"", i, j.JobName)
+		}
+	}
+}
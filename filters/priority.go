@@ -0,0 +1,12 @@
+package filters
+
+import (
+	"github.com/yuriykis/gogridengine"
+)
+
+//NewPriorityAboveFilter returns only jobs whose JATPriority is strictly greater than p.
+func NewPriorityAboveFilter(p float64) func(job gogridengine.Job) bool {
+	return func(job gogridengine.Job) bool {
+		return job.JATPriority > p
+	}
+}
@@ -0,0 +1,19 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/yuriykis/gogridengine"
+)
+
+func TestNewPriorityAboveFilter(t *testing.T) {
+	filter := NewPriorityAboveFilter(0.5)
+
+	if filter(gogridengine.Job{JATPriority: 0.9}) != true {
+		t.Error("expected a job with priority 0.9 to pass a NewPriorityAboveFilter(0.5)")
+	}
+
+	if filter(gogridengine.Job{JATPriority: 0.1}) != false {
+		t.Error("expected a job with priority 0.1 to be filtered out by NewPriorityAboveFilter(0.5)")
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/yuriykis/gogridengine"
 )
 
+//ISO8601FMT is the time.Parse layout for the timestamp format SGE reports in JB_submission_time/JAT_start_time, eg "2021-05-12T10:15:23"
+const ISO8601FMT string = "2006-01-02T15:04:05"
+
 //NewBeforeSubmitTimeFilter returns only jobs whose submitted time occurs before the provided time.
 func NewBeforeSubmitTimeFilter(t time.Time) func(job gogridengine.Job) bool {
 	return func(job gogridengine.Job) bool {
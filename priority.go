@@ -0,0 +1,56 @@
+package gogridengine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+//SortByPriority sorts the list by JATPriority. Pass desc=true for highest priority first.
+func (jl JobList) SortByPriority(desc bool) JobList {
+	sort.Slice(jl[:], func(i, j int) bool {
+		if desc {
+			return jl[i].JATPriority > jl[j].JATPriority
+		}
+
+		return jl[i].JATPriority < jl[j].JATPriority
+	})
+
+	return jl
+}
+
+//TopN returns the first n Jobs in the list. It does not sort the list itself, so callers wanting the
+//highest priority jobs should call SortByPriority(true) first.
+func (jl JobList) TopN(n int) JobList {
+	if n >= len(jl) {
+		return jl
+	}
+
+	if n <= 0 {
+		return JobList{}
+	}
+
+	return jl[:n]
+}
+
+//SetJobPriority changes a pending job's priority via qalter -p. Per SGE semantics, priority changes on array
+//jobs only apply to tasks that have not yet been scheduled, mirroring the distinction DoesJobContainTaskRange
+//already draws between scheduled and pending task ranges.
+func SetJobPriority(jobID int64, prio float64) error {
+	out, err := commandRunner("qalter", "-p", strconv.FormatFloat(prio, 'f', -1, 64), strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return fmt.Errorf("qalter -p failed for job %d: %w (%s)", jobID, err, string(out))
+	}
+
+	return nil
+}
+
+//Reschedule requeues a running or pending job via qmod -rj, causing the scheduler to re-evaluate its placement
+func Reschedule(jobID int64) error {
+	out, err := commandRunner("qmod", "-rj", strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return fmt.Errorf("qmod -rj failed for job %d: %w (%s)", jobID, err, string(out))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package gogridengine
+
+import "testing"
+
+func TestJobListSortByPriority(t *testing.T) {
+	jl := JobList{
+		{JBJobNumber: 1, JATPriority: 0.5},
+		{JBJobNumber: 2, JATPriority: 0.9},
+		{JBJobNumber: 3, JATPriority: 0.1},
+	}
+
+	desc := jl.SortByPriority(true)
+	wantDesc := []int64{2, 1, 3}
+	for i, id := range wantDesc {
+		if desc[i].JBJobNumber != id {
+			t.Fatalf("desc[%d].JBJobNumber = %d, want %d", i, desc[i].JBJobNumber, id)
+		}
+	}
+
+	asc := jl.SortByPriority(false)
+	wantAsc := []int64{3, 1, 2}
+	for i, id := range wantAsc {
+		if asc[i].JBJobNumber != id {
+			t.Fatalf("asc[%d].JBJobNumber = %d, want %d", i, asc[i].JBJobNumber, id)
+		}
+	}
+}
+
+func TestJobListTopN(t *testing.T) {
+	jl := JobList{
+		{JBJobNumber: 1},
+		{JBJobNumber: 2},
+		{JBJobNumber: 3},
+	}
+
+	if got := jl.TopN(2); len(got) != 2 || got[0].JBJobNumber != 1 || got[1].JBJobNumber != 2 {
+		t.Fatalf("TopN(2) = %+v, want first two jobs", got)
+	}
+
+	if got := jl.TopN(10); len(got) != 3 {
+		t.Fatalf("TopN(10) = %+v, want all 3 jobs when n exceeds length", got)
+	}
+
+	if got := jl.TopN(0); len(got) != 0 {
+		t.Fatalf("TopN(0) = %+v, want an empty list", got)
+	}
+}
+
+func TestSetJobPriorityAndReschedule(t *testing.T) {
+	var gotArgs []string
+	withFakeCommandRunner(t, func(name string, args ...string) ([]byte, error) {
+		gotArgs = append([]string{name}, args...)
+		return nil, nil
+	})
+
+	if err := SetJobPriority(42, 0.75); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotArgs[0] != "qalter" || gotArgs[1] != "-p" {
+		t.Fatalf("got args %v, want qalter -p ...", gotArgs)
+	}
+
+	if err := Reschedule(42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotArgs[0] != "qmod" || gotArgs[1] != "-rj" {
+		t.Fatalf("got args %v, want qmod -rj ...", gotArgs)
+	}
+}
@@ -0,0 +1,58 @@
+package gogridengine
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+//Queue is a single queue instance (a "Queue-List" entry) as reported by qstat, eg all.q@hostname
+type Queue struct {
+	XMLName xml.Name `xml:"Queue-List" json:"-"`
+	//Name is the queue instance identifier, eg "all.q@hostname.domain"
+	Name string `xml:"name" json:"name"`
+	//QType is the queue type string, eg "BIP"
+	QType string `xml:"qtype,omitempty" json:"qtype,omitempty"`
+	//State is the combined queue instance state string (eg "a" alarm, "d" disabled, "au" alarm+unknown)
+	State         string       `xml:"state,omitempty" json:"state,omitempty"`
+	SlotsUsed     int32        `xml:"slots_used" json:"slots_used"`
+	SlotsTotal    int32        `xml:"slots_total" json:"slots_total"`
+	SlotsReserved int32        `xml:"slots_resv,omitempty" json:"slots_resv,omitempty"`
+	Resources     ResourceList `xml:"resource_list>resource,omitempty" json:"resource_list,omitempty"`
+	JobList       JobList      `xml:"job_list,omitempty" json:"job_list,omitempty"`
+}
+
+//QueueInfo is the collection of scheduled (running) queue instances returned by qstat
+type QueueInfo struct {
+	XMLName xml.Name `xml:"queue_info" json:"-"`
+	Queues  []Queue  `xml:"Queue-List" json:"queue_list"`
+}
+
+//PendingJobs is the collection of unscheduled (pending) jobs returned by qstat
+type PendingJobs struct {
+	XMLName xml.Name `xml:"job_info" json:"-"`
+	JobList JobList  `xml:"job_list,omitempty" json:"job_list,omitempty"`
+}
+
+//JobInfo is the root element of qstat's XML output (`qstat -xml -f`), holding both the scheduled queues and the
+//unscheduled (pending) jobs.
+type JobInfo struct {
+	XMLName     xml.Name    `xml:"job_info" json:"-"`
+	QueueInfo   QueueInfo   `xml:"queue_info" json:"queue_info"`
+	PendingJobs PendingJobs `xml:"job_info" json:"job_info"`
+}
+
+//GetJobInfo shells out to `qstat -xml -f` and parses the result into a JobInfo, the root structure GetJobs and
+//friends walk to build flattened JobLists.
+func GetJobInfo() (JobInfo, error) {
+	out, err := commandRunner("qstat", "-xml", "-f")
+	if err != nil {
+		return JobInfo{}, fmt.Errorf("qstat failed: %w (%s)", err, string(out))
+	}
+
+	var ji JobInfo
+	if err := xml.Unmarshal(out, &ji); err != nil {
+		return JobInfo{}, err
+	}
+
+	return ji, nil
+}
@@ -0,0 +1,251 @@
+package gogridengine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ComplexValueKind identifies which field of a ComplexValue is populated
+type ComplexValueKind int
+
+const (
+	//ComplexString is a plain string value (SGE types STRING, CSTRING, RESTRING, HOST)
+	ComplexString ComplexValueKind = iota
+	//ComplexInt is a whole number value (SGE type INT)
+	ComplexInt
+	//ComplexFloat is a floating point value (SGE types DOUBLE, FLOAT)
+	ComplexFloat
+	//ComplexBool is a true/false value (SGE type BOOL)
+	ComplexBool
+	//ComplexDuration is a SGE hh:mm:ss duration value (SGE type TIME)
+	ComplexDuration
+	//ComplexStorage is a scaled byte value such as 10.2G (SGE type MEMORY)
+	ComplexStorage
+)
+
+//ComplexValue is a tagged union over the value types SGE's complex attributes can report. Only the field
+//matching Kind is populated.
+type ComplexValue struct {
+	Kind     ComplexValueKind
+	String   string
+	Int      int64
+	Float    float64
+	Bool     bool
+	Duration time.Duration
+	Storage  StorageValue
+}
+
+//complexValueKinds maps the standard SGE complex attribute names to the value type they report. The `type` attr
+//on Resource is the complex's *class* (hl/qf/qc: host-level, queue-fixed, queue-consumable), not its value type,
+//so it can't drive this dispatch -- the value type has to be keyed by name instead, the same way
+//NumberofProcessors/TotalMemory/etc. already hardcode per-key parsing.
+var complexValueKinds = map[string]ComplexValueKind{
+	"load_short":      ComplexFloat,
+	"load_medium":     ComplexFloat,
+	"load_long":       ComplexFloat,
+	"np_load_avg":     ComplexFloat,
+	"np_load_short":   ComplexFloat,
+	"np_load_medium":  ComplexFloat,
+	"np_load_long":    ComplexFloat,
+	"cpu":             ComplexFloat,
+	"num_proc":        ComplexInt,
+	"m_core":          ComplexInt,
+	"m_thread":        ComplexInt,
+	"m_socket":        ComplexInt,
+	"display_win_gui": ComplexBool,
+	"mem_free":        ComplexStorage,
+	"mem_total":       ComplexStorage,
+	"mem_used":        ComplexStorage,
+	"swap_free":       ComplexStorage,
+	"swap_total":      ComplexStorage,
+	"swap_used":       ComplexStorage,
+	"virtual_free":    ComplexStorage,
+	"virtual_total":   ComplexStorage,
+	"h_rt":            ComplexDuration,
+	"s_rt":            ComplexDuration,
+	"h_cpu":           ComplexDuration,
+	"s_cpu":           ComplexDuration,
+	"arch":            ComplexString,
+	"os_name":         ComplexString,
+	"m_topology":      ComplexString,
+}
+
+//Complex looks up a resource by name and decodes it according to the value type the grid engine is known to
+//report for that name (see complexValueKinds), returning a ComplexValue tagged with the appropriate variant.
+//Names this package doesn't have a type mapping for decode as ComplexString.
+func (r ResourceList) Complex(name string) (ComplexValue, error) {
+	resource, err := r.locateKey(name)
+	if err != nil {
+		return ComplexValue{}, err
+	}
+
+	switch complexValueKinds[name] {
+	case ComplexInt:
+		v, err := strconv.ParseInt(resource.Value, 10, 64)
+		if err != nil {
+			return ComplexValue{}, err
+		}
+		return ComplexValue{Kind: ComplexInt, Int: v}, nil
+
+	case ComplexFloat:
+		v, err := strconv.ParseFloat(resource.Value, 64)
+		if err != nil {
+			return ComplexValue{}, err
+		}
+		return ComplexValue{Kind: ComplexFloat, Float: v}, nil
+
+	case ComplexBool:
+		v, err := parseSGEBool(resource.Value)
+		if err != nil {
+			return ComplexValue{}, err
+		}
+		return ComplexValue{Kind: ComplexBool, Bool: v}, nil
+
+	case ComplexDuration:
+		v, err := parseSGEDuration(resource.Value)
+		if err != nil {
+			return ComplexValue{}, err
+		}
+		return ComplexValue{Kind: ComplexDuration, Duration: v}, nil
+
+	case ComplexStorage:
+		v, err := newStorageValue(resource.Value)
+		if err != nil {
+			return ComplexValue{}, err
+		}
+		return ComplexValue{Kind: ComplexStorage, Storage: v}, nil
+
+	default:
+		return ComplexValue{Kind: ComplexString, String: resource.Value}, nil
+	}
+}
+
+//parseSGEBool decodes the 0/1 string SGE reports for BOOL typed complexes
+func parseSGEBool(value string) (bool, error) {
+	switch value {
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
+	default:
+		return strconv.ParseBool(value)
+	}
+}
+
+//parseSGEDuration decodes the hh:mm:ss string SGE reports for TIME typed complexes
+func parseSGEDuration(value string) (time.Duration, error) {
+	pieces := strings.Split(value, ":")
+	if len(pieces) != 3 {
+		return 0, fmt.Errorf("Could not parse %q as a hh:mm:ss duration", value)
+	}
+
+	hours, err := strconv.Atoi(pieces[0])
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := strconv.Atoi(pieces[1])
+	if err != nil {
+		return 0, err
+	}
+
+	seconds, err := strconv.Atoi(pieces[2])
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+//NPLoadAvg returns the type safe value for the np_load_avg complex, the load average normalized by num_proc
+func (r ResourceList) NPLoadAvg() (float64, error) {
+	return r.getFloatValueFromList("np_load_avg")
+}
+
+//CPUUsage returns the type safe value (percent) for the cpu complex
+func (r ResourceList) CPUUsage() (float64, error) {
+	return r.getFloatValueFromList("cpu")
+}
+
+//HLArch returns the host's reported architecture string (the arch complex)
+func (r ResourceList) HLArch() (string, error) {
+	resource, err := r.locateKey("arch")
+	if err != nil {
+		return "", err
+	}
+
+	return resource.Value, nil
+}
+
+//HLOperatingSystem returns the host's reported operating system string (the os_name complex)
+func (r ResourceList) HLOperatingSystem() (string, error) {
+	resource, err := r.locateKey("os_name")
+	if err != nil {
+		return "", err
+	}
+
+	return resource.Value, nil
+}
+
+//HLDisplay returns whether the host can serve a graphical display (the display_win_gui complex)
+func (r ResourceList) HLDisplay() (bool, error) {
+	resource, err := r.locateKey("display_win_gui")
+	if err != nil {
+		return false, err
+	}
+
+	return parseSGEBool(resource.Value)
+}
+
+//NumCores returns the type safe value for the m_core complex, the number of physical cores on the host
+func (r ResourceList) NumCores() (int64, error) {
+	return r.getIntegerValueFromList("m_core")
+}
+
+//NumThreads returns the type safe value for the m_thread complex, the number of hardware threads on the host
+func (r ResourceList) NumThreads() (int64, error) {
+	return r.getIntegerValueFromList("m_thread")
+}
+
+//Topology is the parsed representation of the m_topology complex, a string such as "SCCTT" describing the
+//host's sockets, cores and threads. Lowercase letters in the source string denote units excluded from scheduling
+//and are not counted here.
+type Topology struct {
+	Sockets int
+	Cores   int
+	Threads int
+}
+
+//Topology returns the parsed m_topology complex for the host
+func (r ResourceList) Topology() (Topology, error) {
+	resource, err := r.locateKey("m_topology")
+	if err != nil {
+		return Topology{}, err
+	}
+
+	return parseTopology(resource.Value)
+}
+
+//parseTopology counts the reserved-for-use (uppercase) socket/core/thread markers in a m_topology string
+func parseTopology(value string) (Topology, error) {
+	if value == "" {
+		return Topology{}, errors.New("Could not parse an empty m_topology value")
+	}
+
+	var t Topology
+	for _, c := range value {
+		switch c {
+		case 'S':
+			t.Sockets++
+		case 'C':
+			t.Cores++
+		case 'T':
+			t.Threads++
+		}
+	}
+
+	return t, nil
+}
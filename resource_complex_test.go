@@ -0,0 +1,77 @@
+package gogridengine
+
+import "testing"
+
+func TestComplexDispatchesByName(t *testing.T) {
+	resources := ResourceList{
+		{Name: "num_proc", Type: "hl", Value: "16"},
+		{Name: "np_load_avg", Type: "hl", Value: "0.50"},
+		{Name: "display_win_gui", Type: "hl", Value: "0"},
+		{Name: "mem_total", Type: "hl", Value: "64.0G"},
+		{Name: "arch", Type: "hl", Value: "lx-amd64"},
+		{Name: "some_custom_string_attr", Type: "qc", Value: "anything"},
+	}
+
+	cases := []struct {
+		name     string
+		wantKind ComplexValueKind
+	}{
+		{"num_proc", ComplexInt},
+		{"np_load_avg", ComplexFloat},
+		{"display_win_gui", ComplexBool},
+		{"mem_total", ComplexStorage},
+		{"arch", ComplexString},
+		{"some_custom_string_attr", ComplexString},
+	}
+
+	for _, c := range cases {
+		got, err := resources.Complex(c.name)
+		if err != nil {
+			t.Fatalf("Complex(%q) returned an error: %v", c.name, err)
+		}
+
+		if got.Kind != c.wantKind {
+			t.Errorf("Complex(%q).Kind = %v, want %v", c.name, got.Kind, c.wantKind)
+		}
+	}
+}
+
+func TestComplexIgnoresResourceTypeAttr(t *testing.T) {
+	// Real qstat output puts the resource *class* (hl/qf/qc) in Type, never a value type like INT or MEMORY.
+	// Complex must still resolve num_proc to an int from the name table, not from Type.
+	resources := ResourceList{
+		{Name: "num_proc", Type: "hl", Value: "8"},
+	}
+
+	got, err := resources.Complex("num_proc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Kind != ComplexInt || got.Int != 8 {
+		t.Fatalf("got %+v, want Kind=ComplexInt Int=8", got)
+	}
+}
+
+func TestParseTopology(t *testing.T) {
+	top, err := parseTopology("SCCTTTTccTT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if top.Sockets != 1 || top.Cores != 2 || top.Threads != 6 {
+		t.Fatalf("got %+v, want Sockets=1 Cores=2 Threads=6", top)
+	}
+}
+
+func TestParseSGEDuration(t *testing.T) {
+	d, err := parseSGEDuration("01:02:03")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := 1*60*60 + 2*60 + 3
+	if int(d.Seconds()) != want {
+		t.Fatalf("got %v seconds, want %d", d.Seconds(), want)
+	}
+}
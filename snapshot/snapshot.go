@@ -0,0 +1,214 @@
+//Package snapshot serializes a gogridengine.JobInfo to a small versioned, compressed on-disk frame format so
+//operators can capture and later replay cluster state, similar in spirit to an etcd backup sidecar.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/yuriykis/gogridengine"
+)
+
+const (
+	//magic identifies a snapshot frame header, "GGE1" in ASCII
+	magic uint32 = 0x47474531
+	//version is the current frame format version
+	version uint16 = 1
+)
+
+//ErrBadMagic is returned when a frame does not begin with the expected magic number
+var ErrBadMagic = errors.New("snapshot: frame does not start with the expected magic number")
+
+//ErrChecksumMismatch is returned when a frame's payload fails its CRC32 check
+var ErrChecksumMismatch = errors.New("snapshot: payload failed CRC32 validation")
+
+//ErrUnsupportedVersion is returned when a frame declares a version this package does not know how to read
+var ErrUnsupportedVersion = errors.New("snapshot: unsupported frame version")
+
+//Write serializes ji as a single framed record to w. The payload is JSON (using the same json tags already
+//present on Resource/Job) and gzip compressed.
+func Write(w io.Writer, ji gogridengine.JobInfo) error {
+	return writeFrame(w, ji, time.Now())
+}
+
+func writeFrame(w io.Writer, ji gogridengine.JobInfo, at time.Time) error {
+	raw, err := json.Marshal(ji)
+	if err != nil {
+		return err
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(raw); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	payload := compressed.Bytes()
+	checksum := crc32.ChecksumIEEE(payload)
+
+	header := struct {
+		Magic    uint32
+		Version  uint16
+		Length   uint32
+		CRC32    uint32
+		UnixNano int64
+	}{
+		Magic:    magic,
+		Version:  version,
+		Length:   uint32(len(payload)),
+		CRC32:    checksum,
+		UnixNano: at.UnixNano(),
+	}
+
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return err
+	}
+
+	_, err = w.Write(payload)
+	return err
+}
+
+//Read parses a single framed record from r, validating its magic number, version and CRC32 before decompressing
+//and unmarshalling it back into a JobInfo.
+func Read(r io.Reader) (gogridengine.JobInfo, error) {
+	var header struct {
+		Magic    uint32
+		Version  uint16
+		Length   uint32
+		CRC32    uint32
+		UnixNano int64
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return gogridengine.JobInfo{}, err
+	}
+
+	if header.Magic != magic {
+		return gogridengine.JobInfo{}, ErrBadMagic
+	}
+
+	if header.Version != version {
+		return gogridengine.JobInfo{}, ErrUnsupportedVersion
+	}
+
+	payload := make([]byte, header.Length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return gogridengine.JobInfo{}, err
+	}
+
+	if crc32.ChecksumIEEE(payload) != header.CRC32 {
+		return gogridengine.JobInfo{}, ErrChecksumMismatch
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return gogridengine.JobInfo{}, err
+	}
+	defer gz.Close()
+
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		return gogridengine.JobInfo{}, err
+	}
+
+	var ji gogridengine.JobInfo
+	if err := json.Unmarshal(raw, &ji); err != nil {
+		return gogridengine.JobInfo{}, err
+	}
+
+	return ji, nil
+}
+
+//Append writes a single framed, timestamped record to the end of path, creating the file if it does not already
+//exist. Calling Append repeatedly against the same path builds a time-series of cluster snapshots that can be
+//replayed in order with Read.
+func Append(path string, ji gogridengine.JobInfo) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("snapshot: opening %s for append: %w", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := writeFrame(w, ji, time.Now()); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+//JobStateChange describes a job whose State differed between two snapshots (StateAttribute is not compared)
+type JobStateChange struct {
+	JobID    int64
+	OldState string
+	NewState string
+}
+
+//Delta is the result of comparing two JobInfo snapshots
+type Delta struct {
+	Added   gogridengine.JobList
+	Removed gogridengine.JobList
+	Changed []JobStateChange
+}
+
+//Diff reports the jobs added, removed, and state-changed between old and new so monitoring tools can emit
+//deltas without keeping their own state.
+func Diff(old, updated gogridengine.JobInfo) Delta {
+	oldJobs := indexByJobNumber(old)
+	newJobs := indexByJobNumber(updated)
+
+	var delta Delta
+
+	for id, job := range newJobs {
+		if _, ok := oldJobs[id]; !ok {
+			delta.Added = append(delta.Added, job)
+		}
+	}
+
+	for id, job := range oldJobs {
+		if _, ok := newJobs[id]; !ok {
+			delta.Removed = append(delta.Removed, job)
+		}
+	}
+
+	for id, newJob := range newJobs {
+		if oldJob, ok := oldJobs[id]; ok && oldJob.State != newJob.State {
+			delta.Changed = append(delta.Changed, JobStateChange{
+				JobID:    id,
+				OldState: oldJob.State,
+				NewState: newJob.State,
+			})
+		}
+	}
+
+	return delta
+}
+
+//indexByJobNumber flattens a JobInfo's running and pending jobs into a map keyed by JBJobNumber
+func indexByJobNumber(ji gogridengine.JobInfo) map[int64]gogridengine.Job {
+	jobs := make(map[int64]gogridengine.Job)
+
+	for _, q := range ji.QueueInfo.Queues {
+		for _, j := range q.JobList {
+			jobs[j.JBJobNumber] = j
+		}
+	}
+
+	for _, j := range ji.PendingJobs.JobList {
+		jobs[j.JBJobNumber] = j
+	}
+
+	return jobs
+}
@@ -0,0 +1,148 @@
+package snapshot
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/yuriykis/gogridengine"
+)
+
+func sampleJobInfo(state string) gogridengine.JobInfo {
+	return gogridengine.JobInfo{
+		QueueInfo: gogridengine.QueueInfo{
+			Queues: []gogridengine.Queue{
+				{
+					Name: "all.q@host1",
+					JobList: gogridengine.JobList{
+						{JBJobNumber: 1, State: state, JobName: "a"},
+					},
+				},
+			},
+		},
+		PendingJobs: gogridengine.PendingJobs{
+			JobList: gogridengine.JobList{
+				{JBJobNumber: 2, State: "qw", JobName: "b"},
+			},
+		},
+	}
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	want := sampleJobInfo("r")
+
+	var buf bytes.Buffer
+	if err := Write(&buf, want); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read returned an error: %v", err)
+	}
+
+	if len(got.QueueInfo.Queues) != 1 || got.QueueInfo.Queues[0].JobList[0].JBJobNumber != 1 {
+		t.Fatalf("got %+v, want a round trip of %+v", got, want)
+	}
+
+	if len(got.PendingJobs.JobList) != 1 || got.PendingJobs.JobList[0].JBJobNumber != 2 {
+		t.Fatalf("pending jobs did not round trip: %+v", got.PendingJobs)
+	}
+}
+
+func TestReadRejectsBadMagic(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleJobInfo("r")); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	corrupted := buf.Bytes()
+	corrupted[0] ^= 0xFF
+
+	_, err := Read(bytes.NewReader(corrupted))
+	if err != ErrBadMagic {
+		t.Fatalf("got error %v, want ErrBadMagic", err)
+	}
+}
+
+func TestReadRejectsBadChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleJobInfo("r")); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	corrupted := buf.Bytes()
+	// flip a byte inside the payload, after the fixed-size header
+	corrupted[len(corrupted)-1] ^= 0xFF
+
+	_, err := Read(bytes.NewReader(corrupted))
+	if err != ErrChecksumMismatch {
+		t.Fatalf("got error %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestAppendBuildsAReplayableTimeSeries(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/snapshots.bin"
+
+	if err := Append(path, sampleJobInfo("r")); err != nil {
+		t.Fatalf("first Append returned an error: %v", err)
+	}
+	if err := Append(path, sampleJobInfo("d")); err != nil {
+		t.Fatalf("second Append returned an error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("could not open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	first, err := Read(f)
+	if err != nil {
+		t.Fatalf("reading first frame: %v", err)
+	}
+	second, err := Read(f)
+	if err != nil {
+		t.Fatalf("reading second frame: %v", err)
+	}
+
+	if first.QueueInfo.Queues[0].JobList[0].State != "r" {
+		t.Fatalf("first frame state = %q, want \"r\"", first.QueueInfo.Queues[0].JobList[0].State)
+	}
+	if second.QueueInfo.Queues[0].JobList[0].State != "d" {
+		t.Fatalf("second frame state = %q, want \"d\"", second.QueueInfo.Queues[0].JobList[0].State)
+	}
+}
+
+func TestDiffReportsAddedRemovedAndChanged(t *testing.T) {
+	old := gogridengine.JobInfo{
+		PendingJobs: gogridengine.PendingJobs{
+			JobList: gogridengine.JobList{
+				{JBJobNumber: 1, State: "qw"},
+				{JBJobNumber: 2, State: "qw"},
+			},
+		},
+	}
+
+	updated := gogridengine.JobInfo{
+		PendingJobs: gogridengine.PendingJobs{
+			JobList: gogridengine.JobList{
+				{JBJobNumber: 1, State: "r"},
+				{JBJobNumber: 3, State: "qw"},
+			},
+		},
+	}
+
+	delta := Diff(old, updated)
+
+	if len(delta.Added) != 1 || delta.Added[0].JBJobNumber != 3 {
+		t.Fatalf("got Added %+v, want job 3", delta.Added)
+	}
+	if len(delta.Removed) != 1 || delta.Removed[0].JBJobNumber != 2 {
+		t.Fatalf("got Removed %+v, want job 2", delta.Removed)
+	}
+	if len(delta.Changed) != 1 || delta.Changed[0].JobID != 1 || delta.Changed[0].OldState != "qw" || delta.Changed[0].NewState != "r" {
+		t.Fatalf("got Changed %+v, want job 1 qw->r", delta.Changed)
+	}
+}
@@ -0,0 +1,227 @@
+package gogridengine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	//JOBSUBMITTEDREGEX matches the job number (and, for array jobs, the task range) echoed back by qsub
+	JOBSUBMITTEDREGEX string = `Your job(?:-array)? ([0-9]+)(?:\.([0-9]+-[0-9]+:[0-9]+))? `
+)
+
+//commandRunner is an injectable seam so tests can fake out the qsub/qalter/qdel/qhold/qrls binaries without shelling out.
+var commandRunner = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+//JobSpec describes the flags accepted by qsub when submitting new work into the grid engine.
+type JobSpec struct {
+	//Name is passed via -N
+	Name string
+	//Queue is passed via -q, left blank to let the scheduler pick
+	Queue string
+	//Slots requests a parallel environment slot count via -pe <PE> <Slots>. PE is required when Slots is non-zero.
+	PE    string
+	Slots int32
+	//HardResources are resource requests that must be satisfied, passed via -l as a comma separated name=value list.
+	//Names should match the same Resource names exposed by ResourceList (eg "mem_free", "num_proc")
+	HardResources map[string]string
+	//SoftResources are resource requests passed via -soft -l, honored on a best-effort basis
+	SoftResources map[string]string
+	//ArrayRange, when non-empty, submits an array job via -t using the same "N-M:S" syntax parsed by ExtrapolateTasksToJobs
+	ArrayRange string
+	//Holds lists job numbers this job should be held on via -hold_jid
+	Holds []int64
+	//Meta is passed through as environment variables via -v NAME=VALUE
+	Meta map[string]string
+	//Command is the binary or script to submit. When empty, qsub is invoked without a script, which is only valid for array jobs referencing a prior -t template.
+	Command string
+	//Args are passed through to Command unmodified
+	Args []string
+}
+
+//SubmittedJob is the result of a successful SubmitJob (parsed from qsub's output) or DispatchJob (the template job ID) call.
+type SubmittedJob struct {
+	JobID int64
+	//TaskRange is populated when the submission was an array job, eg "1-10:1"
+	TaskRange string
+}
+
+//buildSubmitArgs renders a JobSpec down to the qsub argument list
+func buildSubmitArgs(spec JobSpec) ([]string, error) {
+	var args []string
+
+	if spec.Name != "" {
+		args = append(args, "-N", spec.Name)
+	}
+
+	if spec.Queue != "" {
+		args = append(args, "-q", spec.Queue)
+	}
+
+	if spec.Slots > 0 {
+		if spec.PE == "" {
+			return nil, errors.New("JobSpec.PE is required when JobSpec.Slots is non-zero")
+		}
+		args = append(args, "-pe", spec.PE, strconv.Itoa(int(spec.Slots)))
+	}
+
+	if len(spec.HardResources) > 0 {
+		args = append(args, "-l", joinResourceMap(spec.HardResources))
+	}
+
+	if len(spec.SoftResources) > 0 {
+		args = append(args, "-soft", "-l", joinResourceMap(spec.SoftResources))
+	}
+
+	if spec.ArrayRange != "" {
+		args = append(args, "-t", spec.ArrayRange)
+	}
+
+	for _, hold := range spec.Holds {
+		args = append(args, "-hold_jid", strconv.FormatInt(hold, 10))
+	}
+
+	for k, v := range spec.Meta {
+		args = append(args, "-v", fmt.Sprintf("%s=%s", k, v))
+	}
+
+	if spec.Command != "" {
+		args = append(args, spec.Command)
+		args = append(args, spec.Args...)
+	}
+
+	return args, nil
+}
+
+func joinResourceMap(resources map[string]string) string {
+	pairs := make([]string, 0, len(resources))
+	for k, v := range resources {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+//SubmitJob shells out to qsub with the flags described by spec and returns the parsed job number (and task range, for array jobs)
+func SubmitJob(spec JobSpec) (SubmittedJob, error) {
+	args, err := buildSubmitArgs(spec)
+	if err != nil {
+		return SubmittedJob{}, err
+	}
+
+	out, err := commandRunner("qsub", args...)
+	if err != nil {
+		return SubmittedJob{}, fmt.Errorf("qsub failed: %w (%s)", err, string(out))
+	}
+
+	return parseSubmitOutput(out)
+}
+
+//parseSubmitOutput extracts the job number (and task range, for array jobs) from qsub's stdout
+func parseSubmitOutput(out []byte) (SubmittedJob, error) {
+	regex, err := regexp.Compile(JOBSUBMITTEDREGEX)
+	if err != nil {
+		return SubmittedJob{}, err
+	}
+
+	matches := regex.FindStringSubmatch(string(out))
+	if matches == nil {
+		return SubmittedJob{}, fmt.Errorf("Could not parse a job number from qsub output: %s", string(out))
+	}
+
+	jobID, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return SubmittedJob{}, err
+	}
+
+	return SubmittedJob{JobID: jobID, TaskRange: matches[2]}, nil
+}
+
+//DispatchJob submits a parameterized run of an already-queued template job, modeled after Nomad's parameterized/dispatch pattern.
+//meta is passed through to the instance as -v NAME=VALUE environment variables. When payload is non-empty it is
+//staged to a temporary file on the submitting host, and that file's path is exposed to the job as the
+//GGE_DISPATCH_PAYLOAD -v environment variable; the job script is responsible for reading (and cleaning up) that
+//file. templateJobID must already be a held (qsub -h) job awaiting its first task -- DispatchJob clears the hold
+//via Release once qalter succeeds.
+func DispatchJob(templateJobID int64, meta map[string]string, payload []byte) (SubmittedJob, error) {
+	var args []string
+
+	for k, v := range meta {
+		args = append(args, "-v", fmt.Sprintf("%s=%s", k, v))
+	}
+
+	if len(payload) > 0 {
+		path, err := stageDispatchPayload(payload)
+		if err != nil {
+			return SubmittedJob{}, err
+		}
+
+		args = append(args, "-v", fmt.Sprintf("GGE_DISPATCH_PAYLOAD=%s", path))
+	}
+
+	args = append(args, strconv.FormatInt(templateJobID, 10))
+
+	out, err := commandRunner("qalter", args...)
+	if err != nil {
+		return SubmittedJob{}, fmt.Errorf("qalter failed dispatching job %d: %w (%s)", templateJobID, err, string(out))
+	}
+
+	if err := Release(templateJobID); err != nil {
+		return SubmittedJob{}, err
+	}
+
+	return SubmittedJob{JobID: templateJobID}, nil
+}
+
+//stageDispatchPayload writes payload to a temporary file and returns its path, since qalter has no mechanism for
+//handing a job instance arbitrary bytes directly.
+func stageDispatchPayload(payload []byte) (string, error) {
+	f, err := os.CreateTemp("", "gogridengine-dispatch-*")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(payload); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+//Delete removes a job from the grid engine entirely via qdel
+func Delete(jobID int64) error {
+	out, err := commandRunner("qdel", strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return fmt.Errorf("qdel failed for job %d: %w (%s)", jobID, err, string(out))
+	}
+
+	return nil
+}
+
+//Hold places a user hold on a job via qhold, the symmetric counterpart to Release
+func Hold(jobID int64) error {
+	out, err := commandRunner("qhold", strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return fmt.Errorf("qhold failed for job %d: %w (%s)", jobID, err, string(out))
+	}
+
+	return nil
+}
+
+//Release clears a user hold on a job via qrls, the symmetric counterpart to Hold
+func Release(jobID int64) error {
+	out, err := commandRunner("qrls", strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return fmt.Errorf("qrls failed for job %d: %w (%s)", jobID, err, string(out))
+	}
+
+	return nil
+}
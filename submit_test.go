@@ -0,0 +1,169 @@
+package gogridengine
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func withFakeCommandRunner(t *testing.T, fn func(name string, args ...string) ([]byte, error)) {
+	t.Helper()
+
+	original := commandRunner
+	commandRunner = fn
+	t.Cleanup(func() { commandRunner = original })
+}
+
+func TestParseSubmitOutput(t *testing.T) {
+	cases := []struct {
+		name    string
+		out     string
+		want    SubmittedJob
+		wantErr bool
+	}{
+		{
+			name: "plain job",
+			out:  "Your job 1234 (\"myjob\") has been submitted\n",
+			want: SubmittedJob{JobID: 1234},
+		},
+		{
+			name: "array job",
+			out:  "Your job-array 5678.1-10:1 (\"myarray\") has been submitted\n",
+			want: SubmittedJob{JobID: 5678, TaskRange: "1-10:1"},
+		},
+		{
+			name:    "unparseable",
+			out:     "qsub: error: no such queue\n",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseSubmitOutput([]byte(c.out))
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != c.want {
+				t.Fatalf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestBuildSubmitArgsRequiresPEWithSlots(t *testing.T) {
+	_, err := buildSubmitArgs(JobSpec{Slots: 4})
+	if err == nil {
+		t.Fatal("expected an error when Slots is set without PE")
+	}
+}
+
+func TestSubmitJobUsesCommandRunner(t *testing.T) {
+	var gotName string
+	var gotArgs []string
+
+	withFakeCommandRunner(t, func(name string, args ...string) ([]byte, error) {
+		gotName = name
+		gotArgs = args
+		return []byte("Your job 42 (\"t\") has been submitted\n"), nil
+	})
+
+	got, err := SubmitJob(JobSpec{Name: "t"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.JobID != 42 {
+		t.Fatalf("got job ID %d, want 42", got.JobID)
+	}
+
+	if gotName != "qsub" {
+		t.Fatalf("got command %q, want qsub", gotName)
+	}
+
+	if !reflect.DeepEqual(gotArgs, []string{"-N", "t"}) {
+		t.Fatalf("got args %v, want [-N t]", gotArgs)
+	}
+}
+
+func TestDeleteHoldReleasePropagateFailure(t *testing.T) {
+	withFakeCommandRunner(t, func(name string, args ...string) ([]byte, error) {
+		return []byte("denied: does not exist"), errors.New("exit status 1")
+	})
+
+	if err := Delete(1); err == nil {
+		t.Error("expected Delete to propagate the command failure")
+	}
+
+	if err := Hold(1); err == nil {
+		t.Error("expected Hold to propagate the command failure")
+	}
+
+	if err := Release(1); err == nil {
+		t.Error("expected Release to propagate the command failure")
+	}
+}
+
+func TestDispatchJobStagesPayloadAndReleasesHold(t *testing.T) {
+	var qalterArgs []string
+	var released bool
+
+	withFakeCommandRunner(t, func(name string, args ...string) ([]byte, error) {
+		switch name {
+		case "qalter":
+			qalterArgs = args
+			return nil, nil
+		case "qrls":
+			released = true
+			return nil, nil
+		default:
+			t.Fatalf("unexpected command %q", name)
+			return nil, nil
+		}
+	})
+
+	_, err := DispatchJob(99, map[string]string{"RUN": "1"}, []byte("hello"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !released {
+		t.Error("expected DispatchJob to release the template job's hold")
+	}
+
+	if len(qalterArgs) == 0 || qalterArgs[len(qalterArgs)-1] != "99" {
+		t.Fatalf("expected the job_identifier (99) to be the last qalter argument, after its -v options, got %v", qalterArgs)
+	}
+
+	var payloadPath string
+	for i, a := range qalterArgs {
+		if a == "-v" && i+1 < len(qalterArgs) {
+			if len(qalterArgs[i+1]) > len("GGE_DISPATCH_PAYLOAD=") && qalterArgs[i+1][:len("GGE_DISPATCH_PAYLOAD=")] == "GGE_DISPATCH_PAYLOAD=" {
+				payloadPath = qalterArgs[i+1][len("GGE_DISPATCH_PAYLOAD="):]
+			}
+		}
+	}
+
+	if payloadPath == "" {
+		t.Fatal("expected a GGE_DISPATCH_PAYLOAD argument to be passed to qalter")
+	}
+
+	contents, err := os.ReadFile(payloadPath)
+	if err != nil {
+		t.Fatalf("could not read staged payload file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(payloadPath) })
+
+	if string(contents) != "hello" {
+		t.Fatalf("got staged payload %q, want %q", contents, "hello")
+	}
+}
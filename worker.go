@@ -0,0 +1,138 @@
+package gogridengine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+//WorkerStat is a consolidated "worker view" of a single host, collapsed from every queue instance running on it.
+type WorkerStat struct {
+	Host string
+	//CPUUtilization is load_medium / num_proc, 0 when num_proc is unavailable
+	CPUUtilization float64
+	//MemoryUtilization is mem_used / mem_total expressed as a percentage, 0 when mem_total is unavailable
+	MemoryUtilization float64
+	SlotsUsed         int32
+	SlotsTotal        int32
+	//SlotUtilization is SlotsUsed / SlotsTotal expressed as a percentage, 0 when SlotsTotal is zero
+	SlotUtilization float64
+	//Alarm reflects whether any queue instance on the host reported the "a" (alarm) state attribute
+	Alarm bool
+	//JobIDs lists every job currently running on the host, across all of its queue instances
+	JobIDs []int64
+}
+
+//WorkerStatList is a slice of WorkerStats that is filterable and sortable via receiver, mirroring JobList.
+type WorkerStatList []WorkerStat
+
+//WorkerStats walks every queue instance reported by GetJobInfo, collapses them down to one WorkerStat per host,
+//and computes utilization so callers get a "cluster at a glance" view instead of iterating QueueInfo.Queues
+//and ResourceList getters by hand.
+func WorkerStats() ([]WorkerStat, error) {
+	ji, err := GetJobInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	byHost := make(map[string]*WorkerStat)
+	var order []string
+
+	for _, q := range ji.QueueInfo.Queues {
+		host := hostFromQName(q.Name)
+
+		stat, ok := byHost[host]
+		if !ok {
+			stat = &WorkerStat{Host: host}
+			byHost[host] = stat
+			order = append(order, host)
+		}
+
+		stat.SlotsUsed += q.SlotsUsed
+		stat.SlotsTotal += q.SlotsTotal
+
+		if strings.Contains(q.State, "a") {
+			stat.Alarm = true
+		}
+
+		if numProc, err := q.Resources.NumberofProcessors(); err == nil && numProc > 0 {
+			if load, err := q.Resources.Load("medium"); err == nil {
+				stat.CPUUtilization = load / float64(numProc)
+			}
+		}
+
+		if used, err := q.Resources.MemoryUsed(); err == nil {
+			if total, err := q.Resources.TotalMemory(); err == nil && total.Bytes > 0 {
+				stat.MemoryUtilization = (float64(used.Bytes) / float64(total.Bytes)) * 100
+			}
+		}
+
+		for _, j := range q.JobList {
+			stat.JobIDs = append(stat.JobIDs, j.JBJobNumber)
+		}
+	}
+
+	stats := make([]WorkerStat, 0, len(order))
+	for _, host := range order {
+		stat := byHost[host]
+		if stat.SlotsTotal > 0 {
+			stat.SlotUtilization = (float64(stat.SlotsUsed) / float64(stat.SlotsTotal)) * 100
+		}
+		stats = append(stats, *stat)
+	}
+
+	return stats, nil
+}
+
+//hostFromQName splits a queue instance name of the form "all.q@hostname.domain" down to just the hostname
+func hostFromQName(qname string) string {
+	pieces := strings.SplitN(qname, "@", 2)
+	if len(pieces) != 2 {
+		return qname
+	}
+
+	return pieces[1]
+}
+
+//SortByUtilization sorts the list by CPUUtilization, descending, and returns the receiver for fluent chaining
+func (wsl WorkerStatList) SortByUtilization() WorkerStatList {
+	sort.Slice(wsl, func(i, j int) bool {
+		return wsl[i].CPUUtilization > wsl[j].CPUUtilization
+	})
+
+	return wsl
+}
+
+//Filter allows for the passage of any function taking a WorkerStat and filtering the list's contents down
+func (wsl WorkerStatList) Filter(filter func(w WorkerStat) bool) WorkerStatList {
+	var filtered WorkerStatList
+
+	for _, v := range wsl {
+		if filter(v) {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
+
+//Render writes a tabwriter formatted summary of the list to w, one row per host
+func (wsl WorkerStatList) Render(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "HOST\tCPU%\tMEM%\tSLOTS\tALARM\tJOBS")
+	for _, stat := range wsl {
+		fmt.Fprintf(tw, "%s\t%.1f\t%.1f\t%d/%d\t%t\t%d\n",
+			stat.Host,
+			stat.CPUUtilization*100,
+			stat.MemoryUtilization,
+			stat.SlotsUsed, stat.SlotsTotal,
+			stat.Alarm,
+			len(stat.JobIDs),
+		)
+	}
+
+	return tw.Flush()
+}
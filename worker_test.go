@@ -0,0 +1,133 @@
+package gogridengine
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHostFromQName(t *testing.T) {
+	cases := map[string]string{
+		"all.q@host1.example.com": "host1.example.com",
+		"all.q":                   "all.q",
+	}
+
+	for qname, want := range cases {
+		if got := hostFromQName(qname); got != want {
+			t.Errorf("hostFromQName(%q) = %q, want %q", qname, got, want)
+		}
+	}
+}
+
+func TestWorkerStatListSortByUtilization(t *testing.T) {
+	wsl := WorkerStatList{
+		{Host: "a", CPUUtilization: 0.2},
+		{Host: "b", CPUUtilization: 0.9},
+		{Host: "c", CPUUtilization: 0.5},
+	}
+
+	sorted := wsl.SortByUtilization()
+
+	want := []string{"b", "c", "a"}
+	for i, host := range want {
+		if sorted[i].Host != host {
+			t.Fatalf("sorted[%d].Host = %q, want %q", i, sorted[i].Host, host)
+		}
+	}
+}
+
+func TestWorkerStatListFilter(t *testing.T) {
+	wsl := WorkerStatList{
+		{Host: "a", Alarm: true},
+		{Host: "b", Alarm: false},
+	}
+
+	filtered := wsl.Filter(func(w WorkerStat) bool { return w.Alarm })
+
+	if len(filtered) != 1 || filtered[0].Host != "a" {
+		t.Fatalf("got %+v, want only host a", filtered)
+	}
+}
+
+func TestWorkerStatListRender(t *testing.T) {
+	wsl := WorkerStatList{
+		{Host: "host1", CPUUtilization: 0.5, MemoryUtilization: 75, SlotsUsed: 2, SlotsTotal: 8, Alarm: true, JobIDs: []int64{1, 2}},
+	}
+
+	var buf bytes.Buffer
+	if err := wsl.Render(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "host1") || !strings.Contains(out, "2/8") || !strings.Contains(out, "true") {
+		t.Fatalf("Render output missing expected fields: %s", out)
+	}
+}
+
+const sampleJobInfoXML = `<?xml version='1.0'?>
+<job_info xmlns:xsd="http://arc.liv.ac.uk/repos/darcs/sge/source/dist/util/resources/schemas/qstat/qstat.xsd">
+  <queue_info>
+    <Queue-List>
+      <name>all.q@host1</name>
+      <qtype>BIP</qtype>
+      <slots_used>2</slots_used>
+      <slots_total>8</slots_total>
+      <state>a</state>
+      <resource_list>
+        <resource name="num_proc" type="hl">4</resource>
+        <resource name="load_medium" type="hl">2.0</resource>
+        <resource name="mem_total" type="hl">16.0G</resource>
+        <resource name="mem_used" type="hl">8.0G</resource>
+      </resource_list>
+      <job_list state="running">
+        <JB_job_number>101</JB_job_number>
+        <state>r</state>
+      </job_list>
+    </Queue-List>
+    <Queue-List>
+      <name>other.q@host1</name>
+      <qtype>BIP</qtype>
+      <slots_used>2</slots_used>
+      <slots_total>8</slots_total>
+    </Queue-List>
+  </queue_info>
+  <job_info>
+  </job_info>
+</job_info>
+`
+
+func TestWorkerStatsCollapsesQueueInstancesPerHost(t *testing.T) {
+	withFakeCommandRunner(t, func(name string, args ...string) ([]byte, error) {
+		return []byte(sampleJobInfoXML), nil
+	})
+
+	stats, err := WorkerStats()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(stats) != 1 {
+		t.Fatalf("got %d worker stats, want 1", len(stats))
+	}
+
+	stat := stats[0]
+	if stat.Host != "host1" {
+		t.Errorf("Host = %q, want host1", stat.Host)
+	}
+	if !stat.Alarm {
+		t.Error("expected Alarm to be true for a queue instance in state \"a\"")
+	}
+	if stat.CPUUtilization != 0.5 {
+		t.Errorf("CPUUtilization = %v, want 0.5 (load_medium 2.0 / num_proc 4)", stat.CPUUtilization)
+	}
+	if stat.MemoryUtilization != 50 {
+		t.Errorf("MemoryUtilization = %v, want 50", stat.MemoryUtilization)
+	}
+	if stat.SlotUtilization != 25 {
+		t.Errorf("SlotUtilization = %v, want 25", stat.SlotUtilization)
+	}
+	if len(stat.JobIDs) != 1 || stat.JobIDs[0] != 101 {
+		t.Errorf("JobIDs = %v, want [101]", stat.JobIDs)
+	}
+}